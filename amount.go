@@ -0,0 +1,154 @@
+package digibyte
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const satoshisPerDGB = 1e8
+
+// Amount is a quantity of DGB expressed in satoshis (1 DGB = 1e8
+// satoshis), used in place of float64 everywhere the API exchanges coin
+// amounts so that values under 1 satoshi can't get rounded or printed in
+// scientific notation.
+type Amount int64
+
+// AmountFromDGB converts a DGB-denominated float, as returned by older
+// RPC fields, into an Amount.
+func AmountFromDGB(dgb float64) Amount {
+	return Amount(math.Round(dgb * satoshisPerDGB))
+}
+
+// AmountFromString parses a decimal DGB string such as "1.23456789" into
+// an Amount, rejecting more than 8 decimal places.
+func AmountFromString(s string) (Amount, error) {
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+
+	parts := strings.SplitN(s, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("digibyte: invalid amount %q: %w", s, err)
+	}
+
+	var frac int64
+	if len(parts) == 2 {
+		fracStr := parts[1]
+		if len(fracStr) > 8 {
+			return 0, fmt.Errorf("digibyte: amount %q has more than 8 decimal places", s)
+		}
+		for len(fracStr) < 8 {
+			fracStr += "0"
+		}
+		frac, err = strconv.ParseInt(fracStr, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("digibyte: invalid amount %q: %w", s, err)
+		}
+	}
+
+	amount := whole*int64(satoshisPerDGB) + frac
+	if neg {
+		amount = -amount
+	}
+	return Amount(amount), nil
+}
+
+// DGB returns the amount in whole DGB, for display or for callers still
+// working in floats.
+func (a Amount) DGB() float64 {
+	return float64(a) / satoshisPerDGB
+}
+
+// String formats the amount as a fixed 8-decimal DGB string, matching
+// what digibyted itself expects for RPC amount parameters.
+func (a Amount) String() string {
+	neg := ""
+	v := int64(a)
+	if v < 0 {
+		neg = "-"
+		v = -v
+	}
+	whole := v / int64(satoshisPerDGB)
+	frac := v % int64(satoshisPerDGB)
+	return fmt.Sprintf("%s%d.%08d", neg, whole, frac)
+}
+
+// MarshalJSON emits the amount as a fixed 8-decimal string so it can be
+// passed directly as a JSON-RPC parameter without float rounding.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts either a JSON number (as digibyted returns DGB
+// amounts) or a decimal string. It parses the decimal text directly with
+// AmountFromString rather than routing through float64, so balances near
+// or above the 21B DGB max supply round-trip without losing precision.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	amount, err := AmountFromString(s)
+	if err == nil {
+		*a = amount
+		return nil
+	}
+
+	// AmountFromString doesn't understand exponent notation; fall back to
+	// float64 only for that rare JSON number form.
+	var f float64
+	if ferr := json.Unmarshal(data, &f); ferr != nil {
+		return err
+	}
+	*a = AmountFromDGB(f)
+	return nil
+}
+
+// GetBalanceDGB is a float64 shim for callers that haven't migrated off
+// DGB floats onto Amount.
+//
+// Deprecated: use GetBalance, which returns an Amount.
+func (b *btcClient) GetBalanceDGB() (float64, error) {
+	amount, err := b.GetBalance()
+	if err != nil {
+		return 0, err
+	}
+	return amount.DGB(), nil
+}
+
+// GetBalanceByAddressDGB is a float64 shim for callers that haven't
+// migrated off DGB floats onto Amount.
+//
+// Deprecated: use GetBalanceByAddress, which returns an Amount.
+func (b *btcClient) GetBalanceByAddressDGB(address string) (float64, error) {
+	amount, err := b.GetBalanceByAddress(address)
+	if err != nil {
+		return 0, err
+	}
+	return amount.DGB(), nil
+}
+
+// SendToAddressDGB is a float64 shim for callers that haven't migrated
+// off DGB floats onto Amount.
+//
+// Deprecated: use SendToAddress with an Amount from AmountFromDGB.
+func (b *btcClient) SendToAddressDGB(address string, amountDGB float64) (string, error) {
+	return b.SendToAddress(address, AmountFromDGB(amountDGB))
+}
+
+// SetFeeDGB is a float64 shim for callers that haven't migrated off DGB
+// floats onto Amount.
+//
+// Deprecated: use SetFee with an Amount from AmountFromDGB.
+func (b *btcClient) SetFeeDGB(feeDGB float64) (bool, error) {
+	return b.SetFee(AmountFromDGB(feeDGB))
+}
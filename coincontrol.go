@@ -0,0 +1,157 @@
+package digibyte
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// maxConfirmations is used as the upper bound for ListUnspent calls that
+// want every spendable output regardless of age.
+const maxConfirmations = int64(9999999)
+
+// defaultConsolidationFee is subtracted from the swept amount so the
+// consolidation transaction built by Consolidate relays successfully.
+const defaultConsolidationFee = 0.001
+
+// UTXO is a single entry returned by listunspent.
+type UTXO struct {
+	Txid          string
+	Vout          int64
+	Address       string
+	ScriptPubKey  string
+	Amount        float64
+	Confirmations int64
+	Spendable     bool
+}
+
+// TxInput references a UTXO to spend in a raw transaction.
+type TxInput struct {
+	Txid string `json:"txid"`
+	Vout int64  `json:"vout"`
+}
+
+func utxoFromResult(m map[string]interface{}) UTXO {
+	u := UTXO{}
+	if val, ok := m["txid"].(string); ok {
+		u.Txid = val
+	}
+	if val, ok := m["vout"]; ok {
+		u.Vout = int64(val.(float64))
+	}
+	if val, ok := m["address"].(string); ok {
+		u.Address = val
+	}
+	if val, ok := m["scriptPubKey"].(string); ok {
+		u.ScriptPubKey = val
+	}
+	if val, ok := m["amount"]; ok {
+		if reflect.TypeOf(val).Name() == "string" {
+			u.Amount, _ = strconv.ParseFloat(val.(string), 64)
+		} else {
+			u.Amount = val.(float64)
+		}
+	}
+	if val, ok := m["confirmations"]; ok {
+		if reflect.TypeOf(val).Name() == "string" {
+			u.Confirmations, _ = strconv.ParseInt(val.(string), 10, 64)
+		} else {
+			u.Confirmations = int64(val.(float64))
+		}
+	}
+	if val, ok := m["spendable"].(bool); ok {
+		u.Spendable = val
+	}
+	return u
+}
+
+// ListUnspent returns unspent outputs with between minConf and maxConf
+// confirmations, restricted to addresses when it is non-empty. Outputs the
+// wallet cannot spend are included with Spendable set to false.
+func (b *btcClient) ListUnspent(minConf, maxConf int64, addresses []string) ([]UTXO, error) {
+	if addresses == nil {
+		addresses = []string{}
+	}
+	results, err := Call[[]map[string]interface{}](context.Background(), b, "listunspent", minConf, maxConf, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]UTXO, 0, len(results))
+	for _, m := range results {
+		utxos = append(utxos, utxoFromResult(m))
+	}
+	return utxos, nil
+}
+
+// CreateRawTransaction builds an unsigned transaction spending inputs and
+// paying outputs, returning its serialized hex.
+func (b *btcClient) CreateRawTransaction(inputs []TxInput, outputs map[string]float64) (string, error) {
+	return Call[string](context.Background(), b, "createrawtransaction", inputs, outputs)
+}
+
+// SignRawTransactionWithWallet signs as many inputs of hex as the wallet
+// holds keys for, returning the signed hex and whether every input was
+// signed.
+func (b *btcClient) SignRawTransactionWithWallet(hex string) (string, bool, error) {
+	type signResult struct {
+		Hex      string `json:"hex"`
+		Complete bool   `json:"complete"`
+	}
+
+	result, err := Call[signResult](context.Background(), b, "signrawtransactionwithwallet", hex)
+	if err != nil {
+		return "", false, err
+	}
+	return result.Hex, result.Complete, nil
+}
+
+// SendRawTransaction broadcasts a fully signed transaction and returns its
+// txid.
+func (b *btcClient) SendRawTransaction(hex string) (string, error) {
+	return Call[string](context.Background(), b, "sendrawtransaction", hex)
+}
+
+// Consolidate sweeps every spendable UTXO with at least minConf
+// confirmations into a single output paying dst, signing and broadcasting
+// the resulting transaction.
+func (b *btcClient) Consolidate(dst string, minConf int64) (string, error) {
+	utxos, err := b.ListUnspent(minConf, maxConfirmations, nil)
+	if err != nil {
+		return "", err
+	}
+
+	inputs := make([]TxInput, 0, len(utxos))
+	var total float64
+	for _, u := range utxos {
+		if !u.Spendable {
+			continue
+		}
+		inputs = append(inputs, TxInput{Txid: u.Txid, Vout: u.Vout})
+		total += u.Amount
+	}
+	if len(inputs) == 0 {
+		return "", BtcError{Code: 500, Message: "no spendable utxos to consolidate"}
+	}
+
+	amount := total - defaultConsolidationFee
+	if amount <= 0 {
+		return "", BtcError{Code: 500, Message: "consolidated amount too small to cover fee"}
+	}
+	outputs := map[string]float64{dst: amount}
+
+	hex, err := b.CreateRawTransaction(inputs, outputs)
+	if err != nil {
+		return "", err
+	}
+
+	signedHex, complete, err := b.SignRawTransactionWithWallet(hex)
+	if err != nil {
+		return "", err
+	}
+	if !complete {
+		return "", BtcError{Code: 500, Message: "could not sign all consolidation inputs"}
+	}
+
+	return b.SendRawTransaction(signedHex)
+}
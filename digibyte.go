@@ -0,0 +1,146 @@
+package digibyte
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/xfishernet/DigiByte/zmq"
+)
+
+type Client interface {
+
+	SetFee(fee Amount) (bool, error)
+	CreateAddress() (string, error)
+	GetBalance() (Amount, error)
+	GetWalletInfo() (*BtcWalletInfo, error)
+	GetBalanceByAddress(address string) (Amount, error)
+	SendToAddress(address string, amount Amount) (string, error)
+	GetTransaction(txid string) (map[string]interface{}, error)
+	CheckTransaction(txid string) (bool, error)
+	ImportAddress(address, label string, rescan bool) error
+	RegisterWalletNotifyHandler(addr string) error
+	Subscribe(ctx context.Context, addresses []string, minConfs int64) (<-chan PaymentEvent, error)
+
+	ListUnspent(minConf, maxConf int64, addresses []string) ([]UTXO, error)
+	CreateRawTransaction(inputs []TxInput, outputs map[string]float64) (string, error)
+	SignRawTransactionWithWallet(hex string) (string, bool, error)
+	SendRawTransaction(hex string) (string, error)
+	Consolidate(dst string, minConf int64) (string, error)
+
+	RegisterZMQSubscriber(endpoints map[string]string) error
+	WatchTransaction(ctx context.Context, txid string) (<-chan TxUpdate, error)
+
+	CallBatch(ctx context.Context, reqs []Request) ([]Response, error)
+
+	// Deprecated: use the Amount-based methods above.
+	GetBalanceDGB() (float64, error)
+	GetBalanceByAddressDGB(address string) (float64, error)
+	SendToAddressDGB(address string, amountDGB float64) (string, error)
+	SetFeeDGB(feeDGB float64) (bool, error)
+
+}
+
+type btcClient struct {
+	Transport     Transport
+	Confirmations int64
+
+	notifyMu     sync.Mutex
+	notifyChans  map[chan string]bool
+	notifyServer *http.Server
+
+	zmqMu         sync.Mutex
+	zmqSub        *zmq.ZMQSubscriber
+	zmqCancel     context.CancelFunc
+	zmqBlockChans map[chan zmq.BlockEvent]bool
+	zmqTxChans    map[chan zmq.TxEvent]bool
+}
+
+type BtcError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+}
+
+type BtcWalletInfo struct {
+	Hdmasterkeyid         string  `json:"hdmasterkeyid"`
+	Walletname            string  `json:"walletname"`
+	Walletversion         int64   `json:"walletversion"`
+	Balance               Amount  `json:"balance"`
+	UnconfirmedBalance    Amount  `json:"unconfirmed_balance"`
+	Keypoololdest         int64   `json:"keypoololdest"`
+	Keypoolsize           int64   `json:"keypoolsize"`
+	ImmatureBalance       Amount  `json:"immature_balance"`
+	Txcount               int64   `json:"txcount"`
+	KeypoolsizeHdInternal int64   `json:"keypoolsize_hd_internal"`
+	Paytxfee              Amount  `json:"paytxfee"`
+}
+
+func (e BtcError) Error() string {
+	return strconv.FormatInt(e.Code, 10) + ": " + e.Message
+}
+
+// NewClient builds a Client using the transport selected by cfg: a
+// UnixSocketTransport when SocketPath is set, a TLSTransport when
+// TLSConfig is set, otherwise a plain HTTPTransport.
+func NewClient(cfg Config) (Client, error) {
+	if cfg.URL == "" && cfg.SocketPath == "" {
+		return nil, fmt.Errorf("digibyte: Config.URL or Config.SocketPath must be set")
+	}
+
+	var transport Transport
+	switch {
+	case cfg.SocketPath != "":
+		transport = NewUnixSocketTransport(cfg)
+	case cfg.TLSConfig != nil:
+		transport = NewTLSTransport(cfg)
+	default:
+		transport = NewHTTPTransport(cfg)
+	}
+
+	return &btcClient{Transport: transport, Confirmations: cfg.Confirmations}, nil
+}
+
+func (b *btcClient) CreateAddress() (string, error) {
+	return Call[string](context.Background(), b, "getnewaddress")
+}
+
+func (b *btcClient) GetBalance() (Amount, error) {
+	return Call[Amount](context.Background(), b, "getbalance")
+}
+
+func (b *btcClient) GetWalletInfo() (*BtcWalletInfo, error) {
+	info, err := Call[BtcWalletInfo](context.Background(), b, "getwalletinfo")
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (b *btcClient) CheckTransaction(txid string) (bool, error) {
+	type txStatus struct {
+		Confirmations int64 `json:"confirmations"`
+	}
+	status, err := Call[txStatus](context.Background(), b, "gettransaction", txid)
+	if err != nil {
+		return false, err
+	}
+	return status.Confirmations >= b.Confirmations, nil
+}
+
+func (b *btcClient) GetBalanceByAddress(address string) (Amount, error) {
+	return Call[Amount](context.Background(), b, "getreceivedbyaddress", address, b.Confirmations)
+}
+
+func (b *btcClient) SendToAddress(address string, amount Amount) (string, error) {
+	return Call[string](context.Background(), b, "sendtoaddress", address, amount)
+}
+
+func (b *btcClient) GetTransaction(txid string) (map[string]interface{}, error) {
+	return Call[map[string]interface{}](context.Background(), b, "gettransaction", txid)
+}
+
+func (b *btcClient) SetFee(fee Amount) (bool, error) {
+	return Call[bool](context.Background(), b, "settxfee", fee)
+}
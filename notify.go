@@ -0,0 +1,198 @@
+package digibyte
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultNotifyPollInterval is how often Subscribe falls back to scanning
+// listtransactions when no walletnotify callback has been registered, or
+// between callbacks to pick up confirmation bumps on already-seen txids.
+const defaultNotifyPollInterval = 15 * time.Second
+
+// PaymentEvent describes an incoming payment observed on a subscribed
+// address, either pushed in by the walletnotify callback server or
+// discovered by polling listtransactions/gettransaction. Confirmed flips
+// to true once Confirmations reaches the minConfs passed to Subscribe.
+type PaymentEvent struct {
+	Txid          string
+	Address       string
+	Amount        float64
+	Confirmations int64
+	Confirmed     bool
+}
+
+// ImportAddress watches address in the wallet without owning its keys, so
+// that Subscribe and listtransactions-based polling can see its activity.
+func (b *btcClient) ImportAddress(address, label string, rescan bool) error {
+	_, err := Call[interface{}](context.Background(), b, "importaddress", address, label, rescan)
+	return err
+}
+
+// RegisterWalletNotifyHandler starts an HTTP server on addr that accepts
+// txid callbacks from digibyted's -walletnotify=curl hook and fans each
+// one out to every active Subscribe call. It's meant to be started once
+// at process startup, alongside -walletnotify='curl -s http://addr/walletnotify?txid=%s'.
+// addr is bound before this returns, so a failure to bind (port already
+// in use, malformed addr, permission denied) is reported to the caller
+// instead of silently leaving the listener down.
+func (b *btcClient) RegisterWalletNotifyHandler(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/walletnotify", func(w http.ResponseWriter, r *http.Request) {
+		txid := r.URL.Query().Get("txid")
+		if txid == "" {
+			body, _ := ioutil.ReadAll(r.Body)
+			txid = strings.TrimSpace(string(body))
+		}
+		if txid != "" {
+			b.notifyMu.Lock()
+			for ch := range b.notifyChans {
+				select {
+				case ch <- txid:
+				default:
+				}
+			}
+			b.notifyMu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{Addr: addr, Handler: mux}
+	b.notifyServer = server
+
+	go server.Serve(ln)
+
+	return nil
+}
+
+// listTransactions returns the count most recent wallet transactions, used
+// by Subscribe as a fallback when no walletnotify callback has arrived.
+func (b *btcClient) listTransactions(count int64) ([]map[string]interface{}, error) {
+	return Call[[]map[string]interface{}](context.Background(), b, "listtransactions", "*", count)
+}
+
+// Subscribe returns a channel of PaymentEvents for the given addresses
+// (all addresses if none are given). It combines txids pushed in via
+// RegisterWalletNotifyHandler with a periodic listtransactions poll, so
+// callers get events whether or not a walletnotify hook is configured.
+// The channel is closed when ctx is done.
+func (b *btcClient) Subscribe(ctx context.Context, addresses []string, minConfs int64) (<-chan PaymentEvent, error) {
+	filter := make(map[string]bool, len(addresses))
+	for _, a := range addresses {
+		filter[a] = true
+	}
+
+	out := make(chan PaymentEvent)
+	notify := make(chan string, 16)
+
+	b.notifyMu.Lock()
+	if b.notifyChans == nil {
+		b.notifyChans = make(map[chan string]bool)
+	}
+	b.notifyChans[notify] = true
+	b.notifyMu.Unlock()
+
+	seen := make(map[string]int64)
+
+	emit := func(txid string) {
+		resp, err := b.GetTransaction(txid)
+		if err != nil {
+			return
+		}
+
+		var confirmations int64
+		if val, ok := resp["confirmations"]; ok {
+			if reflect.TypeOf(val).Name() == "string" {
+				confirmations, _ = strconv.ParseInt(val.(string), 10, 64)
+			} else {
+				confirmations = int64(val.(float64))
+			}
+		}
+		if last, ok := seen[txid]; ok && last == confirmations {
+			return
+		}
+		seen[txid] = confirmations
+
+		amount := 0.0
+		if val, ok := resp["amount"]; ok {
+			if reflect.TypeOf(val).Name() == "string" {
+				amount, _ = strconv.ParseFloat(val.(string), 64)
+			} else {
+				amount = val.(float64)
+			}
+		}
+
+		address := ""
+		if details, ok := resp["details"].([]interface{}); ok {
+			for _, d := range details {
+				if dm, ok := d.(map[string]interface{}); ok {
+					if a, ok := dm["address"].(string); ok {
+						if len(filter) == 0 || filter[a] {
+							address = a
+							break
+						}
+					}
+				}
+			}
+		}
+		if len(filter) > 0 && address == "" {
+			return
+		}
+
+		select {
+		case out <- PaymentEvent{
+			Txid:          txid,
+			Address:       address,
+			Amount:        amount,
+			Confirmations: confirmations,
+			Confirmed:     confirmations >= minConfs,
+		}:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(defaultNotifyPollInterval)
+		defer ticker.Stop()
+		defer close(out)
+		defer func() {
+			b.notifyMu.Lock()
+			delete(b.notifyChans, notify)
+			b.notifyMu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case txid := <-notify:
+				emit(txid)
+			case <-ticker.C:
+				txs, err := b.listTransactions(100)
+				if err != nil {
+					continue
+				}
+				for _, tx := range txs {
+					if val, ok := tx["txid"]; ok {
+						if txid, ok := val.(string); ok {
+							emit(txid)
+						}
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,186 @@
+package digibyte
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxIdleConns = 10
+)
+
+// Config describes how to reach a digibyted JSON-RPC endpoint. URL plus
+// either User/Password or CookieFile select an HTTPTransport; setting
+// TLSConfig selects a TLSTransport; setting SocketPath selects a
+// UnixSocketTransport.
+type Config struct {
+	URL        string
+	User       string
+	Password   string
+	CookieFile string
+	SocketPath string
+	TLSConfig  *tls.Config
+
+	Timeout      time.Duration
+	MaxIdleConns int
+
+	Confirmations int64
+}
+
+func (c Config) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultTimeout
+}
+
+func (c Config) maxIdleConns() int {
+	if c.MaxIdleConns > 0 {
+		return c.MaxIdleConns
+	}
+	return defaultMaxIdleConns
+}
+
+// Transport sends a single marshaled JSON-RPC request body and returns the
+// raw response body.
+type Transport interface {
+	Do(ctx context.Context, body []byte) ([]byte, error)
+}
+
+// HTTPTransport posts JSON-RPC requests over plain HTTP(S) using a shared,
+// keep-alive http.Client. Credentials come from User/Password, or, when
+// CookieFile is set, from a cookie file that's re-read before every
+// request the way bitcoind refreshes its own .cookie on restart.
+type HTTPTransport struct {
+	URL        string
+	User       string
+	Password   string
+	CookieFile string
+	Client     *http.Client
+}
+
+// NewHTTPTransport builds an HTTPTransport from cfg with a connection-pooled
+// http.Client.
+func NewHTTPTransport(cfg Config) *HTTPTransport {
+	return &HTTPTransport{
+		URL:        cfg.URL,
+		User:       cfg.User,
+		Password:   cfg.Password,
+		CookieFile: cfg.CookieFile,
+		Client: &http.Client{
+			Timeout: cfg.timeout(),
+			Transport: &http.Transport{
+				MaxIdleConns:        cfg.maxIdleConns(),
+				MaxIdleConnsPerHost: cfg.maxIdleConns(),
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}
+}
+
+func (t *HTTPTransport) credentials() (string, string, error) {
+	if t.CookieFile == "" {
+		return t.User, t.Password, nil
+	}
+
+	data, err := ioutil.ReadFile(t.CookieFile)
+	if err != nil {
+		return "", "", err
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(data)), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("digibyte: malformed cookie file %s", t.CookieFile)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (t *HTTPTransport) Do(ctx context.Context, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", t.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	user, password, err := t.credentials()
+	if err != nil {
+		return nil, err
+	}
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// TLSTransport is an HTTPTransport whose client enforces cfg.TLSConfig,
+// for talking to a digibyted behind a TLS-terminating proxy.
+type TLSTransport struct {
+	HTTPTransport
+}
+
+// NewTLSTransport builds a TLSTransport from cfg.
+func NewTLSTransport(cfg Config) *TLSTransport {
+	return &TLSTransport{HTTPTransport{
+		URL:        cfg.URL,
+		User:       cfg.User,
+		Password:   cfg.Password,
+		CookieFile: cfg.CookieFile,
+		Client: &http.Client{
+			Timeout: cfg.timeout(),
+			Transport: &http.Transport{
+				TLSClientConfig:     cfg.TLSConfig,
+				MaxIdleConns:        cfg.maxIdleConns(),
+				MaxIdleConnsPerHost: cfg.maxIdleConns(),
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}}
+}
+
+// UnixSocketTransport is an HTTPTransport dialed over a unix domain socket
+// at cfg.SocketPath instead of TCP, for a digibyted running on the same
+// host with RPC bound to a socket rather than a port.
+type UnixSocketTransport struct {
+	HTTPTransport
+}
+
+// NewUnixSocketTransport builds a UnixSocketTransport from cfg.
+func NewUnixSocketTransport(cfg Config) *UnixSocketTransport {
+	dialer := &net.Dialer{}
+	url := cfg.URL
+	if url == "" {
+		url = "http://unix/"
+	}
+	return &UnixSocketTransport{HTTPTransport{
+		URL:        url,
+		User:       cfg.User,
+		Password:   cfg.Password,
+		CookieFile: cfg.CookieFile,
+		Client: &http.Client{
+			Timeout: cfg.timeout(),
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", cfg.SocketPath)
+				},
+				MaxIdleConns:        cfg.maxIdleConns(),
+				MaxIdleConnsPerHost: cfg.maxIdleConns(),
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}}
+}
@@ -0,0 +1,191 @@
+// Package zmq subscribes to digibyted's ZMQ publishers
+// (-zmqpubhashblock, -zmqpubhashtx, -zmqpubrawblock, -zmqpubrawtx) and
+// delivers decoded block/tx notifications on Go channels, so callers can
+// wait for confirmations instead of polling gettransaction on a timer.
+package zmq
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	zmq4 "github.com/go-zeromq/zmq4"
+)
+
+// Topic names match digibyted's -zmqpub* flag suffixes and are the keys
+// NewZMQSubscriber expects in its endpoints map.
+const (
+	TopicHashBlock = "hashblock"
+	TopicHashTx    = "hashtx"
+	TopicRawBlock  = "rawblock"
+	TopicRawTx     = "rawtx"
+)
+
+const (
+	minBackoff = 1 * time.Second
+	maxBackoff = 60 * time.Second
+)
+
+// BlockEvent is a decoded hashblock notification.
+type BlockEvent struct {
+	Hash     string
+	Sequence uint32
+}
+
+// TxEvent is a decoded hashtx notification.
+type TxEvent struct {
+	Txid     string
+	Sequence uint32
+}
+
+// ZMQSubscriber maintains one SUB connection per endpoint and fans their
+// decoded notifications out onto Blocks() and Txs(). Each connection
+// reconnects on failure with exponential backoff from 1s up to 60s.
+type ZMQSubscriber struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	blocks chan BlockEvent
+	txs    chan TxEvent
+
+	wg sync.WaitGroup
+}
+
+// NewZMQSubscriber connects to each endpoint in endpoints, keyed by topic
+// (TopicHashBlock, TopicHashTx, TopicRawBlock, TopicRawTx), matching
+// digibyted's -zmqpubhashblock=<addr>, -zmqpubhashtx=<addr>, etc.
+func NewZMQSubscriber(endpoints map[string]string) (*ZMQSubscriber, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("zmq: at least one endpoint is required")
+	}
+	for topic := range endpoints {
+		switch topic {
+		case TopicHashBlock, TopicHashTx, TopicRawBlock, TopicRawTx:
+		default:
+			return nil, fmt.Errorf("zmq: unknown topic %q", topic)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &ZMQSubscriber{
+		ctx:    ctx,
+		cancel: cancel,
+		blocks: make(chan BlockEvent, 64),
+		txs:    make(chan TxEvent, 64),
+	}
+
+	for topic, addr := range endpoints {
+		s.wg.Add(1)
+		go s.subscribeLoop(topic, addr)
+	}
+
+	return s, nil
+}
+
+// Blocks returns the channel of hashblock notifications.
+func (s *ZMQSubscriber) Blocks() <-chan BlockEvent {
+	return s.blocks
+}
+
+// Txs returns the channel of hashtx notifications.
+func (s *ZMQSubscriber) Txs() <-chan TxEvent {
+	return s.txs
+}
+
+// Close stops every subscription and waits for their goroutines to exit.
+func (s *ZMQSubscriber) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *ZMQSubscriber) subscribeLoop(topic, addr string) {
+	defer s.wg.Done()
+
+	backoff := minBackoff
+	for s.ctx.Err() == nil {
+		err := s.subscribeOnce(topic, addr)
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			backoff = minBackoff
+			continue
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-s.ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (s *ZMQSubscriber) subscribeOnce(topic, addr string) error {
+	sock := zmq4.NewSub(s.ctx)
+	defer sock.Close()
+
+	if err := sock.SetOption(zmq4.OptionSubscribe, topic); err != nil {
+		return err
+	}
+	if err := sock.Dial(addr); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := sock.Recv()
+		if err != nil {
+			return err
+		}
+		if err := s.deliver(topic, msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ZMQSubscriber) deliver(topic string, msg zmq4.Msg) error {
+	if len(msg.Frames) < 2 {
+		return fmt.Errorf("zmq: short message on topic %q", topic)
+	}
+	body := msg.Frames[1]
+
+	var sequence uint32
+	if len(msg.Frames) >= 3 && len(msg.Frames[2]) >= 4 {
+		sequence = binary.LittleEndian.Uint32(msg.Frames[2])
+	}
+
+	switch topic {
+	case TopicHashBlock:
+		select {
+		case s.blocks <- BlockEvent{Hash: reverseHex(body), Sequence: sequence}:
+		case <-s.ctx.Done():
+		}
+	case TopicHashTx:
+		select {
+		case s.txs <- TxEvent{Txid: reverseHex(body), Sequence: sequence}:
+		case <-s.ctx.Done():
+		}
+	case TopicRawBlock, TopicRawTx:
+		// Raw payloads aren't decoded here; the matching hash topic
+		// already carries the identifier callers need.
+	}
+	return nil
+}
+
+// reverseHex hex-encodes b after reversing it, since digibyted (like
+// bitcoind) publishes block and tx hashes internally byte-reversed from
+// their familiar big-endian display form.
+func reverseHex(b []byte) string {
+	reversed := make([]byte, len(b))
+	for i, v := range b {
+		reversed[len(b)-1-i] = v
+	}
+	return hex.EncodeToString(reversed)
+}
@@ -0,0 +1,180 @@
+package digibyte
+
+import (
+	"context"
+	"time"
+
+	"github.com/xfishernet/DigiByte/zmq"
+)
+
+// watchPollInterval is the safety-net poll period for WatchTransaction,
+// in case a ZMQ subscriber was never registered or a notification for
+// txid's block/tx never arrives.
+const watchPollInterval = 5 * time.Second
+
+// TxUpdate is one confirmation-count observation delivered by
+// WatchTransaction.
+type TxUpdate struct {
+	Txid          string
+	Confirmations int64
+	Confirmed     bool
+}
+
+// RegisterZMQSubscriber connects a ZMQSubscriber for endpoints (see
+// zmq.NewZMQSubscriber) and uses it to drive WatchTransaction. Call it
+// once at startup, alongside -zmqpubhashblock/-zmqpubhashtx. Calling it
+// again replaces and closes the previous subscriber.
+func (b *btcClient) RegisterZMQSubscriber(endpoints map[string]string) error {
+	sub, err := zmq.NewZMQSubscriber(endpoints)
+	if err != nil {
+		return err
+	}
+	fanoutCtx, fanoutCancel := context.WithCancel(context.Background())
+
+	b.zmqMu.Lock()
+	oldSub := b.zmqSub
+	oldCancel := b.zmqCancel
+	b.zmqSub = sub
+	b.zmqCancel = fanoutCancel
+	b.zmqMu.Unlock()
+
+	go b.fanoutZMQ(fanoutCtx, sub)
+
+	if oldCancel != nil {
+		oldCancel()
+	}
+	if oldSub != nil {
+		oldSub.Close()
+	}
+	return nil
+}
+
+// fanoutZMQ reads sub's shared Blocks()/Txs() channels and copies each
+// event out to every channel currently registered by a WatchTransaction
+// call, so multiple concurrent watchers each see every notification
+// instead of racing each other for the single upstream event.
+func (b *btcClient) fanoutZMQ(ctx context.Context, sub *zmq.ZMQSubscriber) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-sub.Blocks():
+			b.zmqMu.Lock()
+			for ch := range b.zmqBlockChans {
+				select {
+				case ch <- ev:
+				default:
+				}
+			}
+			b.zmqMu.Unlock()
+		case ev := <-sub.Txs():
+			b.zmqMu.Lock()
+			for ch := range b.zmqTxChans {
+				select {
+				case ch <- ev:
+				default:
+				}
+			}
+			b.zmqMu.Unlock()
+		}
+	}
+}
+
+// WatchTransaction delivers a TxUpdate each time txid's confirmation
+// count changes, replacing CheckTransaction-style polling with
+// push-based waiting. It reacts to ZMQ hashblock/hashtx notifications
+// when RegisterZMQSubscriber has been called, and always falls back to
+// a periodic gettransaction poll so it still makes progress without one.
+// The channel is closed once txid reaches the client's confirmation
+// threshold or ctx is done.
+func (b *btcClient) WatchTransaction(ctx context.Context, txid string) (<-chan TxUpdate, error) {
+	out := make(chan TxUpdate)
+
+	blockCh := make(chan zmq.BlockEvent, 16)
+	txCh := make(chan zmq.TxEvent, 16)
+
+	b.zmqMu.Lock()
+	hasSub := b.zmqSub != nil
+	if hasSub {
+		if b.zmqBlockChans == nil {
+			b.zmqBlockChans = make(map[chan zmq.BlockEvent]bool)
+		}
+		if b.zmqTxChans == nil {
+			b.zmqTxChans = make(map[chan zmq.TxEvent]bool)
+		}
+		b.zmqBlockChans[blockCh] = true
+		b.zmqTxChans[txCh] = true
+	}
+	b.zmqMu.Unlock()
+
+	var blocks <-chan zmq.BlockEvent
+	var txs <-chan zmq.TxEvent
+	if hasSub {
+		blocks = blockCh
+		txs = txCh
+	}
+
+	go func() {
+		defer close(out)
+		defer func() {
+			if !hasSub {
+				return
+			}
+			b.zmqMu.Lock()
+			delete(b.zmqBlockChans, blockCh)
+			delete(b.zmqTxChans, txCh)
+			b.zmqMu.Unlock()
+		}()
+
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		last := int64(-1)
+		check := func() bool {
+			resp, err := b.GetTransaction(txid)
+			if err != nil {
+				return false
+			}
+			var confirmations int64
+			if val, ok := resp["confirmations"].(float64); ok {
+				confirmations = int64(val)
+			}
+			if confirmations == last {
+				return confirmations >= b.Confirmations
+			}
+			last = confirmations
+
+			update := TxUpdate{Txid: txid, Confirmations: confirmations, Confirmed: confirmations >= b.Confirmations}
+			select {
+			case out <- update:
+			case <-ctx.Done():
+			}
+			return update.Confirmed
+		}
+
+		if check() {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-blocks:
+				if check() {
+					return
+				}
+			case <-txs:
+				if check() {
+					return
+				}
+			case <-ticker.C:
+				if check() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}